@@ -0,0 +1,40 @@
+//  Copyright 2026 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package domain
+
+import "fmt"
+
+// PreconditionFailedError is returned by StorageClientInterface and StorageObject writes and
+// deletes when the caller supplied storage.Conditions that the object no longer satisfies, e.g.
+// its generation changed between when the caller observed it and when the request was sent.
+// Callers that need to distinguish "the object moved under me" from "the object is gone" should
+// check for this type rather than inspecting the underlying googleapi.Error.
+type PreconditionFailedError struct {
+	// GcsPath is the gs:// path of the object the precondition was evaluated against.
+	GcsPath string
+	// Cause is the underlying error returned by the storage client, typically a
+	// *googleapi.Error with Code 412.
+	Cause error
+}
+
+// Error implements the error interface.
+func (e *PreconditionFailedError) Error() string {
+	return fmt.Sprintf("precondition failed for %q: %v", e.GcsPath, e.Cause)
+}
+
+// Unwrap allows errors.Is/errors.As to reach the underlying cause.
+func (e *PreconditionFailedError) Unwrap() error {
+	return e.Cause
+}
@@ -23,24 +23,132 @@ import (
 )
 
 // StorageClientInterface represents GCS storage client
+// To rebuild the mock, run `go generate ./...`
+//
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/mock_storage_client.go github.com/GoogleCloudPlatform/compute-image-import/cli_tools/common/domain StorageClientInterface,StorageObject,ObjectIteratorInterface,BucketIteratorInterface
 type StorageClientInterface interface {
-	CreateBucket(bucketName string, project string, attrs *storage.BucketAttrs) error
-	UpdateBucket(bucketName string, attrs storage.BucketAttrsToUpdate) error
-	Buckets(projectID string) *storage.BucketIterator
-	GetBucketAttrs(bucket string) (*storage.BucketAttrs, error)
-	GetBucket(bucket string) *storage.BucketHandle
+	CreateBucket(ctx context.Context, bucketName string, project string, attrs *storage.BucketAttrs) error
+	UpdateBucket(ctx context.Context, bucketName string, attrs storage.BucketAttrsToUpdate) error
+	Buckets(ctx context.Context, projectID string) *storage.BucketIterator
+	GetBucketAttrs(ctx context.Context, bucket string) (*storage.BucketAttrs, error)
+	GetBucket(ctx context.Context, bucket string) *storage.BucketHandle
 	GetObject(bucket string, objectPath string) StorageObject
-	GetObjects(bucket string, objectPath string) ObjectIteratorInterface
-	GetObjectAttrs(bucket string, objectPath string) (*storage.ObjectAttrs, error)
-	FindGcsFile(gcsDirectoryPath string, fileExtension string) (*storage.ObjectHandle, error)
-	FindGcsFileDepthLimited(gcsDirectoryPath string, fileExtension string, lookupDepth int) (*storage.ObjectHandle, error)
-	GetGcsFileContent(gcsObject *storage.ObjectHandle) ([]byte, error)
-	WriteToGCS(destinationBucketName string, destinationObjectPath string, reader io.Reader) error
-	DeleteGcsPath(gcsPath string) error
-	DeleteObject(gcsPath string) error
+	GetObjects(ctx context.Context, bucket string, objectPath string) ObjectIteratorInterface
+	GetObjectAttrs(ctx context.Context, bucket string, objectPath string) (*storage.ObjectAttrs, error)
+
+	// FindGcsFile and FindGcsFileDepthLimited return promptly with ctx.Err() if ctx is
+	// canceled or its deadline passes before a match is found. opts overrides the client's
+	// default RetryPolicy for this call; both are read-only and safe to retry without an
+	// idempotency guard.
+	FindGcsFile(ctx context.Context, gcsDirectoryPath string, fileExtension string, opts ...RetryOption) (*storage.ObjectHandle, error)
+	FindGcsFileDepthLimited(ctx context.Context, gcsDirectoryPath string, fileExtension string, lookupDepth int, opts ...RetryOption) (*storage.ObjectHandle, error)
+	// GetGcsFileContent reads the full contents of gcsObject, bound to ctx: canceling ctx aborts
+	// the read and the returned error wraps ctx.Err(). opts overrides the client's default
+	// RetryPolicy for this call; a read cut short by io.ErrUnexpectedEOF is retried the same as
+	// any other transient error.
+	GetGcsFileContent(ctx context.Context, gcsObject *storage.ObjectHandle, opts ...RetryOption) ([]byte, error)
+
+	// WriteToGCS writes the contents of reader to the given bucket and object path. When
+	// conditions is non-nil, the write is rejected unless the conditions hold, e.g.
+	// &storage.Conditions{DoesNotExist: true} to guard against clobbering an existing object.
+	// opts overrides the client's default RetryPolicy for this call. Retrying is refused unless
+	// conditions is non-nil or the policy sets AllowNonIdempotentRetry: without a precondition,
+	// a retried write can't tell "my first attempt actually succeeded" from "someone else wrote
+	// here since", so it would risk silently clobbering their write. Retrying is also refused,
+	// regardless of conditions, if reader doesn't implement io.Seeker: a retry has to replay
+	// reader from the start, and a non-seekable reader may already be partially drained by the
+	// failed attempt.
+	WriteToGCS(ctx context.Context, destinationBucketName string, destinationObjectPath string, reader io.Reader,
+		conditions *storage.Conditions, opts ...RetryOption) error
+
+	// DeleteGcsPath deletes all objects under gcsPath. conditions, when non-nil, is applied to
+	// every object considered for deletion; objects that no longer satisfy it are left in place
+	// and reported as part of the returned error. DeleteGcsPath stops issuing new deletes as
+	// soon as ctx is canceled, returning ctx.Err() alongside any deletes already reported.
+	DeleteGcsPath(ctx context.Context, gcsPath string, conditions *storage.Conditions) error
+
+	// DeleteGcsPrefix deletes every object under gcsPath the same way as DeleteGcsPath, but
+	// pages through the listing and dispatches deletes across a worker pool for speed on
+	// prefixes with many thousands of objects, e.g. the shards left behind by a streaming
+	// export. Objects are deleted in batches ordered by descending directory depth, with a
+	// barrier between batches, so a "directory placeholder" object (one literally named like
+	// the prefix itself) is only dispatched once every delete nested under it has been
+	// attempted, leaving a partially-failed delete with a consistent tree rather than a
+	// dangling placeholder with no children; this holds regardless of
+	// DeletePrefixOptions.Parallelism. See domain.DeletePrefixOptions for per-call tuning.
+	DeleteGcsPrefix(ctx context.Context, gcsPath string, opts DeletePrefixOptions) (*DeletePrefixResult, error)
+
+	// MovePrefix moves every object under srcGcsPath to the same relative path under
+	// dstGcsPath, paging through the listing and dispatching moves across a worker pool the
+	// same way DeleteGcsPrefix does. Objects are batched by descending directory depth with a
+	// barrier between batches, so a "directory placeholder" object is only dispatched once
+	// everything nested under it has been attempted, leaving a partially-failed move with a
+	// consistent tree at both ends; this holds regardless of MoveOptions.Parallelism. See
+	// domain.MoveOptions for per-call tuning.
+	MovePrefix(ctx context.Context, srcGcsPath string, dstGcsPath string, opts MoveOptions) (*MovePrefixResult, error)
+
+	// DeleteObject deletes the single object at gcsPath. When conditions is non-nil, the
+	// delete is rejected with a PreconditionFailedError if the object's current generation or
+	// metageneration doesn't match. This lets callers delete a scratch object only if it's
+	// still the one they expect, e.g. the generation they just wrote. opts overrides the
+	// client's default RetryPolicy; see WriteToGCS for why a precondition or
+	// AllowNonIdempotentRetry is required before a failed delete is retried.
+	DeleteObject(ctx context.Context, gcsPath string, conditions *storage.Conditions, opts ...RetryOption) error
 	Close() error
 }
 
+// DeletePrefixOptions tunes the behavior of StorageClientInterface.DeleteGcsPrefix.
+type DeletePrefixOptions struct {
+	// Parallelism is the number of concurrent delete workers. Values <= 1 delete serially.
+	Parallelism int
+	// ContinueOnError, when true, keeps deleting remaining objects after one delete fails and
+	// reports every failure in DeletePrefixResult.Errors. When false (the default), the first
+	// failure stops new deletes from being dispatched.
+	ContinueOnError bool
+	// PageSize caps how many objects are listed per GCS API call. Zero uses the client's
+	// default page size.
+	PageSize int
+	// Filter, when non-nil, is consulted for every listed object; objects for which it returns
+	// false are left alone.
+	Filter func(*storage.ObjectAttrs) bool
+}
+
+// DeletePrefixResult summarizes the outcome of a DeleteGcsPrefix call.
+type DeletePrefixResult struct {
+	// ObjectsDeleted is the number of objects successfully deleted.
+	ObjectsDeleted int
+	// BytesDeleted is the sum of the sizes of the deleted objects.
+	BytesDeleted int64
+	// Errors holds one error per object that failed to delete. Use errors.Join(result.Errors...)
+	// to get a single error value, or inspect them individually to see which objects remain.
+	Errors []error
+}
+
+// MoveOptions tunes the behavior of StorageClientInterface.MovePrefix.
+type MoveOptions struct {
+	// Parallelism is the number of concurrent move workers. Values <= 1 move serially.
+	Parallelism int
+	// ContinueOnError, when true, keeps moving remaining objects after one move fails and
+	// reports every failure in MovePrefixResult.Errors. When false (the default), the first
+	// failure stops new moves from being dispatched.
+	ContinueOnError bool
+	// PageSize caps how many objects are listed per GCS API call. Zero uses the client's
+	// default page size.
+	PageSize int
+}
+
+// MovePrefixResult summarizes the outcome of a MovePrefix call.
+type MovePrefixResult struct {
+	// ObjectsMoved is the number of objects successfully moved.
+	ObjectsMoved int
+	// BytesMoved is the sum of the sizes of the moved objects.
+	BytesMoved int64
+	// Errors holds one error per object that failed to move. Use errors.Join(result.Errors...)
+	// to get a single error value, or inspect them individually to see which objects remain
+	// under srcGcsPath.
+	Errors []error
+}
+
 // BucketIteratorCreatorInterface represents GCS bucket creator
 type BucketIteratorCreatorInterface interface {
 	CreateBucketIterator(ctx context.Context, storageClient StorageClientInterface,
@@ -49,7 +157,7 @@ type BucketIteratorCreatorInterface interface {
 
 // BucketIteratorInterface represents GCS bucket iterator
 type BucketIteratorInterface interface {
-	Next() (*storage.BucketAttrs, error)
+	Next(ctx context.Context) (*storage.BucketAttrs, error)
 }
 
 // ObjectIteratorCreatorInterface represents GCS object iterator creator
@@ -59,13 +167,13 @@ type ObjectIteratorCreatorInterface interface {
 
 // ObjectIteratorInterface represents GCS Object iterator
 type ObjectIteratorInterface interface {
-	Next() (*storage.ObjectAttrs, error)
+	Next(ctx context.Context) (*storage.ObjectAttrs, error)
 }
 
 // TarGcsExtractorInterface represents TAR GCS extractor responsible for extracting TAR archives from GCS to
 // GCS
 type TarGcsExtractorInterface interface {
-	ExtractTarToGcs(tarGcsPath string, destinationGcsPath string) error
+	ExtractTarToGcs(ctx context.Context, tarGcsPath string, destinationGcsPath string) error
 }
 
 // StorageObjectCreatorInterface represents GCS object creator
@@ -75,13 +183,34 @@ type StorageObjectCreatorInterface interface {
 
 // StorageObject represents GCS Object
 type StorageObject interface {
-	Delete() error
+	// Delete removes the object. When conditions is non-nil, the delete is rejected with a
+	// PreconditionFailedError if the object doesn't currently satisfy it.
+	Delete(ctx context.Context, conditions *storage.Conditions) error
 	GetObjectHandle() *storage.ObjectHandle
-	NewReader() (io.ReadCloser, error)
-	NewWriter() io.WriteCloser
+	NewReader(ctx context.Context) (io.ReadCloser, error)
+	// NewWriter returns a writer that creates or overwrites the object. When conditions is
+	// non-nil, the close of the returned writer fails with a PreconditionFailedError if the
+	// object doesn't satisfy it, e.g. &storage.Conditions{DoesNotExist: true}. The write is
+	// bound to ctx: canceling ctx aborts an in-flight write on the next Write or Close.
+	NewWriter(ctx context.Context, conditions *storage.Conditions) io.WriteCloser
 	ObjectName() string
-	Compose(src ...StorageObject) (*storage.ObjectAttrs, error)
-	CopyFrom(src StorageObject) (*storage.ObjectAttrs, error)
+	// Compose combines src into this object. conditions, when non-nil, is applied to this
+	// object as the destination precondition.
+	Compose(ctx context.Context, conditions *storage.Conditions, src ...StorageObject) (*storage.ObjectAttrs, error)
+	// CopyFrom copies src into this object. conditions, when non-nil, is applied to this
+	// object as the destination precondition.
+	CopyFrom(ctx context.Context, conditions *storage.Conditions, src StorageObject) (*storage.ObjectAttrs, error)
+	// Move copies this object into dst and then deletes this object. If the copy fails, only the
+	// source remains; if the copy succeeds but the source delete fails, both remain and the
+	// returned error says so. The source is never deleted before the copy succeeds. conditions, when
+	// non-nil, is applied to dst as the destination precondition, e.g.
+	// &storage.Conditions{DoesNotExist: true} to refuse to clobber an existing object at the
+	// destination. Both the copy and the source delete are anchored to this object's
+	// generation as observed just before the copy, so a concurrent overwrite of the source
+	// aborts the move with the newer version left in place, rather than copying the newer
+	// content to dst while still deleting (or refusing to delete) based on the stale
+	// generation.
+	Move(ctx context.Context, conditions *storage.Conditions, dst StorageObject) (*storage.ObjectAttrs, error)
 }
 
 // MetadataGCEInterface represents GCE metadata
@@ -102,7 +231,7 @@ type ZoneValidatorInterface interface {
 //
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/mock_scratch_bucket_creator.go github.com/GoogleCloudPlatform/compute-image-import/cli_tools/common/domain ScratchBucketCreatorInterface
 type ScratchBucketCreatorInterface interface {
-	CreateScratchBucket(sourceFileFlag string, projectFlag string, fallbackZone string,
+	CreateScratchBucket(ctx context.Context, sourceFileFlag string, projectFlag string, fallbackZone string,
 		enableUniformBucketLevelAccess bool) (string, string, error)
 	IsBucketInProject(project string, bucketName string) bool
 }
@@ -120,8 +249,8 @@ type HTTPClientInterface interface {
 
 // ResourceDeleter checks whether images exist. If so, it deletes them.
 type ResourceDeleter interface {
-	DeleteImagesIfExist(images []Image)
-	DeleteDisksIfExist(disks []Disk)
+	DeleteImagesIfExist(ctx context.Context, images []Image)
+	DeleteDisksIfExist(ctx context.Context, disks []Disk)
 }
 
 // Image holds the project, name, and URI of a GCP disk image.
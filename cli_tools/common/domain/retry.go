@@ -0,0 +1,95 @@
+//  Copyright 2026 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package domain
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// RetryPolicy configures how a StorageClientInterface implementation retries a failing
+// operation. The zero value is not ready to use; start from DefaultRetryPolicy and override
+// only the fields that need to change.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of times an operation is tried, including the first
+	// attempt. Values <= 1 disable retrying.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. Subsequent retries double it, up to
+	// MaxBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries. Zero means no cap.
+	MaxBackoff time.Duration
+	// Jitter is the fraction (0 to 1) of the computed backoff that's added back at random, to
+	// keep many concurrent retries (e.g. the worker pool behind DeleteGcsPrefix) from all
+	// retrying in lockstep.
+	Jitter float64
+	// IsRetryable classifies err as worth retrying. Nil means IsRetryableError.
+	IsRetryable func(err error) bool
+	// AllowNonIdempotentRetry opts a non-idempotent call (WriteToGCS or DeleteObject without
+	// conditions) into retrying anyway. Without it, such calls are tried once regardless of
+	// MaxAttempts, because retrying them blind can silently clobber a write or delete that a
+	// concurrent caller made between the original attempt and the retry.
+	AllowNonIdempotentRetry bool
+}
+
+// DefaultRetryPolicy returns the policy StorageClientInterface implementations use when none is
+// attached at construction: five attempts, 500ms initial backoff doubling up to 30s, 20% jitter,
+// retrying only the error classes IsRetryableError recognizes.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Jitter:         0.2,
+	}
+}
+
+// RetryOption overrides a RetryPolicy for a single call. See WithRetryPolicy, WithMaxAttempts,
+// and WithAllowNonIdempotentRetry.
+type RetryOption func(*RetryPolicy)
+
+// WithRetryPolicy replaces the call's entire retry policy with policy.
+func WithRetryPolicy(policy RetryPolicy) RetryOption {
+	return func(p *RetryPolicy) { *p = policy }
+}
+
+// WithMaxAttempts overrides just the attempt count of the call's retry policy.
+func WithMaxAttempts(maxAttempts int) RetryOption {
+	return func(p *RetryPolicy) { p.MaxAttempts = maxAttempts }
+}
+
+// WithAllowNonIdempotentRetry opts a single WriteToGCS or DeleteObject call without conditions
+// into retrying. See RetryPolicy.AllowNonIdempotentRetry.
+func WithAllowNonIdempotentRetry() RetryOption {
+	return func(p *RetryPolicy) { p.AllowNonIdempotentRetry = true }
+}
+
+// IsRetryableError reports whether err looks like a transient failure worth retrying: a
+// *googleapi.Error with code 429, 500, 502, 503, or 504, or io.ErrUnexpectedEOF from a streaming
+// read cut short.
+func IsRetryableError(err error) bool {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case 429, 500, 502, 503, 504:
+			return true
+		}
+		return false
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,55 @@
+//  Copyright 2026 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+)
+
+func TestIsRetryableError_TransientGoogleapiCodes_AreRetryable(t *testing.T) {
+	for _, code := range []int{429, 500, 502, 503, 504} {
+		assert.True(t, IsRetryableError(&googleapi.Error{Code: code}), "code %d", code)
+	}
+}
+
+func TestIsRetryableError_NotFound_IsNotRetryable(t *testing.T) {
+	assert.False(t, IsRetryableError(&googleapi.Error{Code: 404}))
+}
+
+func TestIsRetryableError_UnexpectedEOF_IsRetryable(t *testing.T) {
+	assert.True(t, IsRetryableError(io.ErrUnexpectedEOF))
+}
+
+func TestIsRetryableError_OtherError_IsNotRetryable(t *testing.T) {
+	assert.False(t, IsRetryableError(fmt.Errorf("boom")))
+}
+
+func TestWithMaxAttempts_OverridesOnlyAttemptCount(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	WithMaxAttempts(1)(&policy)
+	assert.Equal(t, 1, policy.MaxAttempts)
+	assert.Equal(t, DefaultRetryPolicy().InitialBackoff, policy.InitialBackoff)
+}
+
+func TestWithRetryPolicy_ReplacesEntirePolicy(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	WithRetryPolicy(RetryPolicy{MaxAttempts: 2})(&policy)
+	assert.Equal(t, RetryPolicy{MaxAttempts: 2}, policy)
+}
@@ -0,0 +1,120 @@
+//  Copyright 2026 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/compute-image-import/cli_tools/common/domain"
+)
+
+// DeleteGcsPrefix lists every object under gcsPath and deletes them across a worker pool. See
+// domain.StorageClientInterface for the semantics of opts. A call stopped partway through (by
+// ctx cancellation, or a failure with ContinueOnError false) can simply be retried against the
+// same gcsPath: already-deleted objects won't be listed again, so the retry only touches what's
+// left.
+func (c *Client) DeleteGcsPrefix(ctx context.Context, gcsPath string, opts domain.DeletePrefixOptions) (*domain.DeletePrefixResult, error) {
+	bucketName, objectPath, err := splitGCSPath(gcsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	it := c.client.Bucket(bucketName).Objects(ctx, &storage.Query{Prefix: objectPath})
+	if opts.PageSize > 0 {
+		it.PageInfo().MaxSize = opts.PageSize
+	}
+
+	var candidates []*storage.ObjectAttrs
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if opts.Filter != nil && !opts.Filter(attrs) {
+			continue
+		}
+		candidates = append(candidates, attrs)
+	}
+
+	// Batching by descending directory depth, with a barrier between batches, deletes a
+	// "directory placeholder" object (named exactly like the prefix it represents) only after
+	// everything nested under it has been attempted, the same order the distribution GCS driver
+	// uses, so a partial failure leaves a consistent tree. A plain descending sort of the
+	// dispatch order isn't enough to guarantee this once Parallelism > 1, since goroutines for
+	// different objects then race rather than complete in dispatch order.
+	depthBatches := batchByDescendingDepth(candidates)
+
+	parallelism := opts.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	deleteCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	result := &domain.DeletePrefixResult{}
+	var mu sync.Mutex
+	sem := make(chan struct{}, parallelism)
+
+	for _, batch := range depthBatches {
+		var wg sync.WaitGroup
+		for _, attrs := range batch {
+			if deleteCtx.Err() != nil {
+				break
+			}
+
+			attrs := attrs
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				objGcsPath := fmt.Sprintf("gs://%s/%s", bucketName, attrs.Name)
+				delErr := c.DeleteObject(deleteCtx, objGcsPath, nil)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if delErr != nil {
+					result.Errors = append(result.Errors, fmt.Errorf("%s: %w", objGcsPath, delErr))
+					if !opts.ContinueOnError {
+						cancel()
+					}
+					return
+				}
+				result.ObjectsDeleted++
+				result.BytesDeleted += attrs.Size
+			}()
+		}
+		// Wait out every goroutine dispatched in this batch, even one that just canceled
+		// deleteCtx, before deciding whether to stop: the barrier has to hold regardless of why
+		// the batch ended, or a later batch could start deleting while this one's deletes (and
+		// the placeholder guarantee they exist to protect) are still in flight.
+		wg.Wait()
+		if deleteCtx.Err() != nil {
+			break
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,520 @@
+//  Copyright 2026 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package fakestorage provides an in-memory implementation of the storage domain interfaces
+// (cli_tools/common/domain), for use in tests that want to exercise real client code against a
+// fake bucket instead of asserting mock call sequences. It's similar in spirit to
+// fsouza/fake-gcs-server, but embedded in the test process and implemented at the
+// domain-interface level rather than by faking the GCS HTTP API.
+//
+// Five StorageClientInterface methods, GetBucket, Buckets, FindGcsFile, FindGcsFileDepthLimited
+// and GetGcsFileContent, take or return concrete *storage.BucketHandle, *storage.BucketIterator
+// or *storage.ObjectHandle types from cloud.google.com/go/storage that can't be constructed
+// without a real (or HTTP-faked) backend, and the same holds for StorageObject.GetObjectHandle.
+// These methods panic rather than silently returning incorrect values, and each documents this
+// limitation.
+//
+// Client does not fake domain.TarGcsExtractorInterface: this source tree has no
+// TarGcsExtractorInterface implementation to exercise against it, so there's nothing here for a
+// fake to stand in for yet. Add one alongside the real extractor when it lands, rather than
+// faking an interface this package can't verify against anything.
+package fakestorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/compute-image-import/cli_tools/common/domain"
+)
+
+// Fault lets tests make a future operation fail the way a flaky GCS backend would.
+type Fault int
+
+const (
+	// FaultNone performs the operation normally.
+	FaultNone Fault = iota
+	// FaultTransient5xx fails the operation with a retryable *googleapi.Error (Code 503).
+	FaultTransient5xx
+	// FaultDeadlineExceeded fails the operation with context.DeadlineExceeded.
+	FaultDeadlineExceeded
+)
+
+type object struct {
+	name           string
+	generation     int64
+	metageneration int64
+	metadata       map[string]string
+	data           []byte
+}
+
+func (o *object) attrs(bucket string) *storage.ObjectAttrs {
+	return &storage.ObjectAttrs{
+		Bucket:         bucket,
+		Name:           o.name,
+		Size:           int64(len(o.data)),
+		Generation:     o.generation,
+		Metageneration: o.metageneration,
+		Metadata:       o.metadata,
+	}
+}
+
+type bucket struct {
+	name    string
+	objects map[string]*object
+}
+
+// Client is an in-memory implementation of domain.StorageClientInterface.
+type Client struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	faults  map[string]Fault
+}
+
+// NewClient returns an empty fake storage client.
+func NewClient() *Client {
+	return &Client{buckets: map[string]*bucket{}, faults: map[string]Fault{}}
+}
+
+// Seed populates the fake with objects, keyed by gs://bucket/object path, creating buckets as
+// needed. Useful for arranging test fixtures in one call.
+func (c *Client) Seed(contents map[string][]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for gcsPath, data := range contents {
+		bucketName, objectPath := mustSplit(gcsPath)
+		b := c.bucketLocked(bucketName)
+		b.objects[objectPath] = &object{name: objectPath, generation: 1, metageneration: 1, data: data}
+	}
+}
+
+// Snapshot returns the current contents of every object in the fake, keyed by gs://bucket/object
+// path, for use in test assertions.
+func (c *Client) Snapshot() map[string][]byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := map[string][]byte{}
+	for bucketName, b := range c.buckets {
+		for _, o := range b.objects {
+			out[fmt.Sprintf("gs://%s/%s", bucketName, o.name)] = append([]byte(nil), o.data...)
+		}
+	}
+	return out
+}
+
+// InjectFault makes the next write or delete against gcsPath (WriteToGCS or DeleteObject,
+// including those made through a StorageObject or a prefix operation) fail with fault. Reads,
+// attrs lookups and listings don't consume it. The fault is consumed after it fires once.
+func (c *Client) InjectFault(gcsPath string, fault Fault) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.faults[gcsPath] = fault
+}
+
+func (c *Client) consumeFault(gcsPath string) error {
+	c.mu.Lock()
+	fault, ok := c.faults[gcsPath]
+	if ok {
+		delete(c.faults, gcsPath)
+	}
+	c.mu.Unlock()
+	switch fault {
+	case FaultTransient5xx:
+		return &googleapi.Error{Code: 503, Message: "fake transient error"}
+	case FaultDeadlineExceeded:
+		return context.DeadlineExceeded
+	default:
+		return nil
+	}
+}
+
+func (c *Client) bucketLocked(name string) *bucket {
+	b, ok := c.buckets[name]
+	if !ok {
+		b = &bucket{name: name, objects: map[string]*object{}}
+		c.buckets[name] = b
+	}
+	return b
+}
+
+// CreateBucket creates an empty bucket. attrs is accepted for interface compatibility but not
+// inspected.
+func (c *Client) CreateBucket(ctx context.Context, bucketName string, project string, attrs *storage.BucketAttrs) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bucketLocked(bucketName)
+	return nil
+}
+
+// UpdateBucket is a no-op in the fake; bucket-level attrs aren't modeled.
+func (c *Client) UpdateBucket(ctx context.Context, bucketName string, attrs storage.BucketAttrsToUpdate) error {
+	return ctx.Err()
+}
+
+// Buckets is not supported by the fake; see the package doc comment.
+func (c *Client) Buckets(ctx context.Context, projectID string) *storage.BucketIterator {
+	panic("fakestorage: Buckets is not supported; it requires a real *storage.BucketIterator")
+}
+
+// GetBucketAttrs returns placeholder attrs for bucket if it exists.
+func (c *Client) GetBucketAttrs(ctx context.Context, bucket string) (*storage.BucketAttrs, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.buckets[bucket]; !ok {
+		return nil, fmt.Errorf("fakestorage: bucket %q does not exist", bucket)
+	}
+	return &storage.BucketAttrs{Name: bucket}, nil
+}
+
+// GetBucket is not supported by the fake; see the package doc comment.
+func (c *Client) GetBucket(ctx context.Context, bucket string) *storage.BucketHandle {
+	panic("fakestorage: GetBucket is not supported; it requires a real *storage.BucketHandle")
+}
+
+// GetObject returns a domain.StorageObject bound to bucket/objectPath. The object need not
+// exist yet; NewWriter will create it.
+func (c *Client) GetObject(bucket string, objectPath string) domain.StorageObject {
+	return &fakeObject{client: c, bucket: bucket, name: objectPath}
+}
+
+// GetObjects returns an iterator over objects in bucket with the given prefix. ctx is accepted
+// for interface compatibility with the real client, whose underlying SDK iterator is bound to
+// it; the fake's iterator instead checks ctx.Err() on each call to Next.
+func (c *Client) GetObjects(ctx context.Context, bucket string, objectPath string) domain.ObjectIteratorInterface {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var names []string
+	if b, ok := c.buckets[bucket]; ok {
+		for name := range b.objects {
+			if strings.HasPrefix(name, objectPath) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return &objectIterator{client: c, bucket: bucket, names: names}
+}
+
+// GetObjectAttrs returns the attrs of bucket/objectPath.
+func (c *Client) GetObjectAttrs(ctx context.Context, bucket string, objectPath string) (*storage.ObjectAttrs, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	o, err := c.findLocked(bucket, objectPath)
+	if err != nil {
+		return nil, err
+	}
+	return o.attrs(bucket), nil
+}
+
+// FindGcsFile is not supported by the fake and panics: it returns a real *storage.ObjectHandle,
+// which the fake cannot produce.
+func (c *Client) FindGcsFile(ctx context.Context, gcsDirectoryPath string, fileExtension string, opts ...domain.RetryOption) (*storage.ObjectHandle, error) {
+	panic("fakestorage: FindGcsFile is not supported; it requires a real *storage.ObjectHandle")
+}
+
+// FindGcsFileDepthLimited is not supported by the fake and panics, for the same reason as
+// FindGcsFile.
+func (c *Client) FindGcsFileDepthLimited(ctx context.Context, gcsDirectoryPath string, fileExtension string, lookupDepth int, opts ...domain.RetryOption) (*storage.ObjectHandle, error) {
+	panic("fakestorage: FindGcsFileDepthLimited is not supported; it requires a real *storage.ObjectHandle")
+}
+
+// GetGcsFileContent is not supported by the fake: it takes a real *storage.ObjectHandle, which
+// the fake cannot produce. Use GetObject(...).NewReader() in tests instead.
+func (c *Client) GetGcsFileContent(ctx context.Context, gcsObject *storage.ObjectHandle, opts ...domain.RetryOption) ([]byte, error) {
+	panic("fakestorage: GetGcsFileContent is not supported; use GetObject(...).NewReader() instead")
+}
+
+// WriteToGCS writes reader's contents to destinationBucketName/destinationObjectPath, honoring
+// conditions the same way the real client does. ctx is only checked before the write starts;
+// the fake has no I/O to cancel. opts is accepted for interface compatibility; the fake has no
+// transient failures to retry beyond what InjectFault models, so it's not consulted.
+func (c *Client) WriteToGCS(ctx context.Context, destinationBucketName string, destinationObjectPath string, reader io.Reader,
+	conditions *storage.Conditions, opts ...domain.RetryOption) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	gcsPath := fmt.Sprintf("gs://%s/%s", destinationBucketName, destinationObjectPath)
+	if err := c.consumeFault(gcsPath); err != nil {
+		return err
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b := c.bucketLocked(destinationBucketName)
+	existing := b.objects[destinationObjectPath]
+	if err := checkConditionsLocked(gcsPath, existing, conditions); err != nil {
+		return err
+	}
+	gen := int64(1)
+	if existing != nil {
+		gen = existing.generation + 1
+	}
+	b.objects[destinationObjectPath] = &object{name: destinationObjectPath, generation: gen, metageneration: 1, data: data}
+	return nil
+}
+
+// DeleteGcsPath deletes every object under gcsPath.
+func (c *Client) DeleteGcsPath(ctx context.Context, gcsPath string, conditions *storage.Conditions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	bucketName, objectPath := mustSplit(gcsPath)
+
+	c.mu.Lock()
+	var names []string
+	if b, ok := c.buckets[bucketName]; ok {
+		for name := range b.objects {
+			if strings.HasPrefix(name, objectPath) {
+				names = append(names, name)
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	var errs []error
+	for _, name := range names {
+		if err := c.DeleteObject(ctx, fmt.Sprintf("gs://%s/%s", bucketName, name), conditions); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DeleteGcsPrefix deletes every object under gcsPath, honoring opts.ContinueOnError and
+// opts.Filter the same way the real client does. Parallelism and PageSize are accepted for
+// interface compatibility but not exercised: the fake has no API calls to page or parallelize,
+// so it deletes serially in descending key order, which also deletes a "directory placeholder"
+// object after everything nested under it. The real client instead dispatches batches by
+// descending directory depth.
+func (c *Client) DeleteGcsPrefix(ctx context.Context, gcsPath string, opts domain.DeletePrefixOptions) (*domain.DeletePrefixResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	bucketName, objectPath := mustSplit(gcsPath)
+
+	c.mu.Lock()
+	var names []string
+	if b, ok := c.buckets[bucketName]; ok {
+		for name, o := range b.objects {
+			if !strings.HasPrefix(name, objectPath) {
+				continue
+			}
+			if opts.Filter != nil && !opts.Filter(o.attrs(bucketName)) {
+				continue
+			}
+			names = append(names, name)
+		}
+	}
+	c.mu.Unlock()
+
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+
+	result := &domain.DeletePrefixResult{}
+	for _, name := range names {
+		objGcsPath := fmt.Sprintf("gs://%s/%s", bucketName, name)
+		c.mu.Lock()
+		attrs, ok := c.buckets[bucketName].objects[name]
+		c.mu.Unlock()
+		if !ok {
+			continue
+		}
+		size := attrs.attrs(bucketName).Size
+
+		if err := c.DeleteObject(ctx, objGcsPath, nil); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("%s: %w", objGcsPath, err))
+			if !opts.ContinueOnError {
+				break
+			}
+			continue
+		}
+		result.ObjectsDeleted++
+		result.BytesDeleted += size
+	}
+	return result, nil
+}
+
+// MovePrefix moves every object under srcGcsPath to the same relative path under dstGcsPath,
+// honoring opts.ContinueOnError the same way the real client does. Parallelism and PageSize are
+// accepted for interface compatibility but not exercised; see DeleteGcsPrefix's doc comment for
+// why.
+func (c *Client) MovePrefix(ctx context.Context, srcGcsPath string, dstGcsPath string, opts domain.MoveOptions) (*domain.MovePrefixResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	srcBucket, srcPrefix := mustSplit(srcGcsPath)
+	dstBucket, dstPrefix := mustSplit(dstGcsPath)
+
+	c.mu.Lock()
+	var names []string
+	if b, ok := c.buckets[srcBucket]; ok {
+		for name := range b.objects {
+			if strings.HasPrefix(name, srcPrefix) {
+				names = append(names, name)
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+
+	result := &domain.MovePrefixResult{}
+	for _, name := range names {
+		srcObjPath := fmt.Sprintf("gs://%s/%s", srcBucket, name)
+		dstName := dstPrefix + strings.TrimPrefix(name, srcPrefix)
+
+		srcObj := c.GetObject(srcBucket, name)
+		dstObj := c.GetObject(dstBucket, dstName)
+		attrs, err := srcObj.Move(ctx, &storage.Conditions{DoesNotExist: true}, dstObj)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("%s: %w", srcObjPath, err))
+			if !opts.ContinueOnError {
+				break
+			}
+			continue
+		}
+		result.ObjectsMoved++
+		result.BytesMoved += attrs.Size
+	}
+	return result, nil
+}
+
+// DeleteObject deletes the single object at gcsPath, honoring conditions. opts is accepted for
+// interface compatibility; see WriteToGCS for why it's not consulted.
+func (c *Client) DeleteObject(ctx context.Context, gcsPath string, conditions *storage.Conditions, opts ...domain.RetryOption) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := c.consumeFault(gcsPath); err != nil {
+		return err
+	}
+	bucketName, objectPath := mustSplit(gcsPath)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.buckets[bucketName]
+	if !ok {
+		return fmt.Errorf("fakestorage: object %q does not exist", gcsPath)
+	}
+	existing, ok := b.objects[objectPath]
+	if !ok {
+		return fmt.Errorf("fakestorage: object %q does not exist", gcsPath)
+	}
+	if err := checkConditionsLocked(gcsPath, existing, conditions); err != nil {
+		return err
+	}
+	delete(b.objects, objectPath)
+	return nil
+}
+
+// Close is a no-op; the fake holds no external resources.
+func (c *Client) Close() error {
+	return nil
+}
+
+func (c *Client) findLocked(bucketName, objectPath string) (*object, error) {
+	b, ok := c.buckets[bucketName]
+	if !ok {
+		return nil, fmt.Errorf("fakestorage: object %q does not exist", fmt.Sprintf("gs://%s/%s", bucketName, objectPath))
+	}
+	o, ok := b.objects[objectPath]
+	if !ok {
+		return nil, fmt.Errorf("fakestorage: object %q does not exist", fmt.Sprintf("gs://%s/%s", bucketName, objectPath))
+	}
+	return o, nil
+}
+
+// checkConditionsLocked evaluates conditions against existing (nil if the object doesn't exist
+// yet), matching the semantics of storage.ObjectHandle.If. Callers must hold c.mu.
+func checkConditionsLocked(gcsPath string, existing *object, conditions *storage.Conditions) error {
+	if conditions == nil {
+		return nil
+	}
+	fail := func() error {
+		return &domain.PreconditionFailedError{GcsPath: gcsPath, Cause: &googleapi.Error{Code: 412, Message: "precondition failed"}}
+	}
+	if conditions.DoesNotExist && existing != nil {
+		return fail()
+	}
+	if conditions.GenerationMatch != 0 && (existing == nil || existing.generation != conditions.GenerationMatch) {
+		return fail()
+	}
+	if conditions.GenerationNotMatch != 0 && existing != nil && existing.generation == conditions.GenerationNotMatch {
+		return fail()
+	}
+	if conditions.MetagenerationMatch != 0 && (existing == nil || existing.metageneration != conditions.MetagenerationMatch) {
+		return fail()
+	}
+	if conditions.MetagenerationNotMatch != 0 && existing != nil && existing.metageneration == conditions.MetagenerationNotMatch {
+		return fail()
+	}
+	return nil
+}
+
+func mustSplit(gcsPath string) (bucket string, object string) {
+	trimmed := strings.TrimPrefix(gcsPath, "gs://")
+	parts := strings.SplitN(trimmed, "/", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// objectIterator implements domain.ObjectIteratorInterface over a fixed, pre-sorted list of
+// object names snapshotted at creation time.
+type objectIterator struct {
+	client *Client
+	bucket string
+	names  []string
+	pos    int
+}
+
+// Next returns the attrs of the next object, or iterator.Done when exhausted.
+func (it *objectIterator) Next(ctx context.Context) (*storage.ObjectAttrs, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if it.pos >= len(it.names) {
+		return nil, iterator.Done
+	}
+	name := it.names[it.pos]
+	it.pos++
+	it.client.mu.Lock()
+	defer it.client.mu.Unlock()
+	o, err := it.client.findLocked(it.bucket, name)
+	if err != nil {
+		return nil, err
+	}
+	return o.attrs(it.bucket), nil
+}
@@ -0,0 +1,296 @@
+//  Copyright 2026 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package fakestorage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/compute-image-import/cli_tools/common/domain"
+)
+
+func TestSeedAndSnapshot_RoundTrips(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{"gs://bucket/dir/a.txt": []byte("hello")})
+
+	assert.Equal(t, map[string][]byte{"gs://bucket/dir/a.txt": []byte("hello")}, c.Snapshot())
+}
+
+func TestDeleteObject_GenerationMismatch_IsRejected(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{"gs://bucket/scratch.tar": []byte("data")})
+
+	err := c.DeleteObject(context.Background(), "gs://bucket/scratch.tar", &storage.Conditions{GenerationMatch: 999})
+	require.Error(t, err)
+	assert.Equal(t, map[string][]byte{"gs://bucket/scratch.tar": []byte("data")}, c.Snapshot())
+}
+
+func TestDeleteObject_GenerationMatch_Succeeds(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{"gs://bucket/scratch.tar": []byte("data")})
+	attrs, err := c.GetObjectAttrs(context.Background(), "bucket", "scratch.tar")
+	require.NoError(t, err)
+
+	err = c.DeleteObject(context.Background(), "gs://bucket/scratch.tar", &storage.Conditions{GenerationMatch: attrs.Generation})
+	require.NoError(t, err)
+	assert.Empty(t, c.Snapshot())
+}
+
+func TestDeleteGcsPath_PreconditionFailure_IsAPreconditionFailedError(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{"gs://bucket/scratch.tar": []byte("data")})
+
+	err := c.DeleteGcsPath(context.Background(), "gs://bucket/scratch.tar", &storage.Conditions{GenerationMatch: 999})
+
+	var preconditionErr *domain.PreconditionFailedError
+	require.ErrorAs(t, err, &preconditionErr)
+	assert.Equal(t, map[string][]byte{"gs://bucket/scratch.tar": []byte("data")}, c.Snapshot())
+}
+
+func TestGetObjects_IteratesInPrefixOrderThenDone(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{
+		"gs://bucket/logs/a.log": []byte("a"),
+		"gs://bucket/logs/b.log": []byte("b"),
+		"gs://bucket/other.txt":  []byte("c"),
+	})
+
+	it := c.GetObjects(context.Background(), "bucket", "logs/")
+	var names []string
+	for {
+		attrs, err := it.Next(context.Background())
+		if err == iterator.Done {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, attrs.Name)
+	}
+	assert.Equal(t, []string{"logs/a.log", "logs/b.log"}, names)
+}
+
+func TestWriteToGCS_DoesNotExistCondition_RejectsOverwrite(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{"gs://bucket/object": []byte("existing")})
+
+	err := c.WriteToGCS(context.Background(), "bucket", "object", strings.NewReader("new"), &storage.Conditions{DoesNotExist: true})
+	require.Error(t, err)
+}
+
+func TestObject_NewWriterThenNewReader_RoundTrips(t *testing.T) {
+	c := NewClient()
+	obj := c.GetObject("bucket", "object")
+
+	w := obj.NewWriter(context.Background(), nil)
+	_, err := w.Write([]byte("hello world"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	r, err := obj.NewReader(context.Background())
+	require.NoError(t, err)
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(data))
+}
+
+func TestInjectFault_TransientErrorSurfacesOnceThenClears(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{"gs://bucket/object": []byte("data")})
+	c.InjectFault("gs://bucket/object", FaultTransient5xx)
+
+	err := c.DeleteObject(context.Background(), "gs://bucket/object", nil)
+	require.Error(t, err)
+
+	err = c.DeleteObject(context.Background(), "gs://bucket/object", nil)
+	require.NoError(t, err)
+}
+
+func TestDeleteObject_CanceledContext_IsRejectedWithoutDeleting(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{"gs://bucket/object": []byte("data")})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := c.DeleteObject(ctx, "gs://bucket/object", nil)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.NotEmpty(t, c.Snapshot())
+}
+
+func TestDeleteGcsPrefix_DeletesAllMatchingObjects(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{
+		"gs://bucket/shards/0.img": []byte("a"),
+		"gs://bucket/shards/1.img": []byte("bb"),
+		"gs://bucket/other.txt":    []byte("c"),
+	})
+
+	result, err := c.DeleteGcsPrefix(context.Background(), "gs://bucket/shards/", domain.DeletePrefixOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.ObjectsDeleted)
+	assert.EqualValues(t, 3, result.BytesDeleted)
+	assert.Equal(t, map[string][]byte{"gs://bucket/other.txt": []byte("c")}, c.Snapshot())
+}
+
+func TestDeleteGcsPrefix_Filter_SkipsNonMatchingObjects(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{
+		"gs://bucket/shards/0.img": []byte("a"),
+		"gs://bucket/shards/0.tmp": []byte("bb"),
+	})
+
+	result, err := c.DeleteGcsPrefix(context.Background(), "gs://bucket/shards/", domain.DeletePrefixOptions{
+		Filter: func(attrs *storage.ObjectAttrs) bool { return strings.HasSuffix(attrs.Name, ".img") },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.ObjectsDeleted)
+	assert.Equal(t, map[string][]byte{"gs://bucket/shards/0.tmp": []byte("bb")}, c.Snapshot())
+}
+
+func TestDeleteGcsPrefix_ContinueOnError_KeepsDeletingPastAFailure(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{
+		"gs://bucket/shards/0.img": []byte("a"),
+		"gs://bucket/shards/1.img": []byte("b"),
+	})
+	c.InjectFault("gs://bucket/shards/1.img", FaultTransient5xx)
+
+	result, err := c.DeleteGcsPrefix(context.Background(), "gs://bucket/shards/", domain.DeletePrefixOptions{ContinueOnError: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.ObjectsDeleted)
+	assert.Len(t, result.Errors, 1)
+	assert.Equal(t, map[string][]byte{"gs://bucket/shards/1.img": []byte("b")}, c.Snapshot())
+}
+
+func TestObject_Move_CopiesThenDeletesSource(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{"gs://bucket/staging/final.tar": []byte("payload")})
+
+	src := c.GetObject("bucket", "staging/final.tar")
+	dst := c.GetObject("bucket", "final.tar")
+	attrs, err := src.Move(context.Background(), &storage.Conditions{DoesNotExist: true}, dst)
+	require.NoError(t, err)
+	assert.EqualValues(t, len("payload"), attrs.Size)
+	assert.Equal(t, map[string][]byte{"gs://bucket/final.tar": []byte("payload")}, c.Snapshot())
+}
+
+func TestObject_Move_DestinationAlreadyExists_LeavesSourceInPlace(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{
+		"gs://bucket/staging/final.tar": []byte("new"),
+		"gs://bucket/final.tar":         []byte("existing"),
+	})
+
+	src := c.GetObject("bucket", "staging/final.tar")
+	dst := c.GetObject("bucket", "final.tar")
+	_, err := src.Move(context.Background(), &storage.Conditions{DoesNotExist: true}, dst)
+	require.Error(t, err)
+	assert.Equal(t, map[string][]byte{
+		"gs://bucket/staging/final.tar": []byte("new"),
+		"gs://bucket/final.tar":         []byte("existing"),
+	}, c.Snapshot())
+}
+
+func TestObject_Move_SourceOverwrittenAfterAttrsRead_FailsRatherThanCopyingNewerContent(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{"gs://bucket/staging/final.tar": []byte("original")})
+
+	src := c.GetObject("bucket", "staging/final.tar")
+
+	srcAttrs, err := c.GetObjectAttrs(context.Background(), "bucket", "staging/final.tar")
+	require.NoError(t, err)
+
+	// Simulate a concurrent writer overwriting the source between Move's internal attrs read and
+	// its copy: dst's CopyFrom overwrites the source before copying, so the generation observed
+	// by Move is stale by the time the copy reads the source.
+	dst := &beforeCopyObject{
+		StorageObject: c.GetObject("bucket", "final.tar"),
+		beforeCopy: func() {
+			require.NoError(t, c.WriteToGCS(context.Background(), "bucket", "staging/final.tar", strings.NewReader("newer"), nil))
+			require.NotEqual(t, srcAttrs.Generation, mustAttrs(t, c, "bucket", "staging/final.tar").Generation)
+		},
+	}
+
+	_, err = src.Move(context.Background(), nil, dst)
+	require.Error(t, err)
+
+	var preconditionErr *domain.PreconditionFailedError
+	assert.ErrorAs(t, err, &preconditionErr)
+	assert.Equal(t, map[string][]byte{"gs://bucket/staging/final.tar": []byte("newer")}, c.Snapshot())
+}
+
+// beforeCopyObject wraps a domain.StorageObject and runs beforeCopy at the start of CopyFrom.
+type beforeCopyObject struct {
+	domain.StorageObject
+	beforeCopy func()
+}
+
+func (o *beforeCopyObject) CopyFrom(ctx context.Context, conditions *storage.Conditions, src domain.StorageObject) (*storage.ObjectAttrs, error) {
+	o.beforeCopy()
+	return o.StorageObject.CopyFrom(ctx, conditions, src)
+}
+
+func mustAttrs(t *testing.T, c *Client, bucket, object string) *storage.ObjectAttrs {
+	t.Helper()
+	attrs, err := c.GetObjectAttrs(context.Background(), bucket, object)
+	require.NoError(t, err)
+	return attrs
+}
+
+func TestMovePrefix_MovesAllObjectsToRelativeDestinationPath(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{
+		"gs://bucket/staging/0.img": []byte("a"),
+		"gs://bucket/staging/1.img": []byte("bb"),
+		"gs://bucket/other.txt":     []byte("c"),
+	})
+
+	result, err := c.MovePrefix(context.Background(), "gs://bucket/staging/", "gs://bucket/final/", domain.MoveOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.ObjectsMoved)
+	assert.EqualValues(t, 3, result.BytesMoved)
+	assert.Equal(t, map[string][]byte{
+		"gs://bucket/final/0.img": []byte("a"),
+		"gs://bucket/final/1.img": []byte("bb"),
+		"gs://bucket/other.txt":   []byte("c"),
+	}, c.Snapshot())
+}
+
+func TestMovePrefix_ContinueOnError_KeepsMovingPastAFailure(t *testing.T) {
+	c := NewClient()
+	c.Seed(map[string][]byte{
+		"gs://bucket/staging/0.img": []byte("a"),
+		"gs://bucket/staging/1.img": []byte("b"),
+	})
+	c.InjectFault("gs://bucket/staging/1.img", FaultTransient5xx)
+
+	result, err := c.MovePrefix(context.Background(), "gs://bucket/staging/", "gs://bucket/final/", domain.MoveOptions{ContinueOnError: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.ObjectsMoved)
+	assert.Len(t, result.Errors, 1)
+	// The 1.img move copied successfully but failed to delete the source, so both copies
+	// remain; that's reported via result.Errors rather than silently dropped.
+	assert.Equal(t, map[string][]byte{
+		"gs://bucket/staging/1.img": []byte("b"),
+		"gs://bucket/final/1.img":   []byte("b"),
+		"gs://bucket/final/0.img":   []byte("a"),
+	}, c.Snapshot())
+}
@@ -0,0 +1,150 @@
+//  Copyright 2026 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package fakestorage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+
+	"github.com/GoogleCloudPlatform/compute-image-import/cli_tools/common/domain"
+)
+
+// fakeObject implements domain.StorageObject over a Client's in-memory buckets.
+type fakeObject struct {
+	client *Client
+	bucket string
+	name   string
+	// generation, when non-zero, pins reads (NewReader, and so Compose/CopyFrom) to this exact
+	// generation: a read against an object that has since been overwritten fails with a
+	// PreconditionFailedError instead of silently returning the newer content. This mirrors
+	// *storage.ObjectHandle.Generation on the real client.
+	generation int64
+}
+
+func (o *fakeObject) gcsPath() string {
+	return fmt.Sprintf("gs://%s/%s", o.bucket, o.name)
+}
+
+// Delete removes the object, honoring conditions.
+func (o *fakeObject) Delete(ctx context.Context, conditions *storage.Conditions) error {
+	return o.client.DeleteObject(ctx, o.gcsPath(), conditions)
+}
+
+// GetObjectHandle is not supported by the fake; see the package doc comment.
+func (o *fakeObject) GetObjectHandle() *storage.ObjectHandle {
+	panic("fakestorage: GetObjectHandle is not supported; it requires a real *storage.ObjectHandle")
+}
+
+// NewReader returns a reader over the object's current contents. If o is pinned to a generation
+// (see the generation field), a concurrent overwrite since that generation was observed fails the
+// read with a PreconditionFailedError instead of returning the newer content.
+func (o *fakeObject) NewReader(ctx context.Context) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	o.client.mu.Lock()
+	obj, err := o.client.findLocked(o.bucket, o.name)
+	if err != nil {
+		o.client.mu.Unlock()
+		return nil, err
+	}
+	if o.generation != 0 && obj.generation != o.generation {
+		o.client.mu.Unlock()
+		return nil, &domain.PreconditionFailedError{GcsPath: o.gcsPath(), Cause: &googleapi.Error{Code: 412, Message: "precondition failed"}}
+	}
+	data := append([]byte(nil), obj.data...)
+	o.client.mu.Unlock()
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// NewWriter returns a writer that, on Close, creates or overwrites the object subject to
+// conditions.
+func (o *fakeObject) NewWriter(ctx context.Context, conditions *storage.Conditions) io.WriteCloser {
+	return &fakeWriter{ctx: ctx, object: o, conditions: conditions}
+}
+
+// ObjectName returns the object's name.
+func (o *fakeObject) ObjectName() string {
+	return o.name
+}
+
+// Compose concatenates the contents of src, in order, into this object.
+func (o *fakeObject) Compose(ctx context.Context, conditions *storage.Conditions, src ...domain.StorageObject) (*storage.ObjectAttrs, error) {
+	var buf bytes.Buffer
+	for _, s := range src {
+		r, err := s.NewReader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.Copy(&buf, r)
+		r.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := o.client.WriteToGCS(ctx, o.bucket, o.name, &buf, conditions); err != nil {
+		return nil, err
+	}
+	return o.client.GetObjectAttrs(ctx, o.bucket, o.name)
+}
+
+// CopyFrom copies src's contents into this object.
+func (o *fakeObject) CopyFrom(ctx context.Context, conditions *storage.Conditions, src domain.StorageObject) (*storage.ObjectAttrs, error) {
+	return o.Compose(ctx, conditions, src)
+}
+
+// Move copies this object into dst and then deletes this object, honoring conditions and the
+// source-delete precondition the same way the real client does.
+func (o *fakeObject) Move(ctx context.Context, conditions *storage.Conditions, dst domain.StorageObject) (*storage.ObjectAttrs, error) {
+	srcAttrs, err := o.client.GetObjectAttrs(ctx, o.bucket, o.name)
+	if err != nil {
+		return nil, err
+	}
+	// Pin the copy, not just the delete, to the generation observed above: without this, a
+	// concurrent overwrite of the source between the GetObjectAttrs call and the copy would copy
+	// the newer content to dst while the delete below still (correctly) fails on the stale
+	// generation, silently moving the wrong version.
+	pinnedSrc := &fakeObject{client: o.client, bucket: o.bucket, name: o.name, generation: srcAttrs.Generation}
+	attrs, err := dst.CopyFrom(ctx, conditions, pinnedSrc)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.Delete(ctx, &storage.Conditions{GenerationMatch: srcAttrs.Generation}); err != nil {
+		return nil, fmt.Errorf("move %s: copied to destination but failed to delete source: %w", o.gcsPath(), err)
+	}
+	return attrs, nil
+}
+
+// fakeWriter buffers writes in memory and commits them to the backing Client on Close, the same
+// point at which the real GCS writer surfaces precondition failures.
+type fakeWriter struct {
+	ctx        context.Context
+	object     *fakeObject
+	conditions *storage.Conditions
+	buf        bytes.Buffer
+}
+
+func (w *fakeWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *fakeWriter) Close() error {
+	return w.object.client.WriteToGCS(w.ctx, w.object.bucket, w.object.name, &w.buf, w.conditions)
+}
@@ -0,0 +1,125 @@
+//  Copyright 2026 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/compute-image-import/cli_tools/common/domain"
+)
+
+// MovePrefix lists every object under srcGcsPath and moves them to the same relative path under
+// dstGcsPath across a worker pool. See domain.StorageClientInterface for the semantics of opts.
+// A call stopped partway through can be retried against the same srcGcsPath/dstGcsPath pair:
+// objects already moved won't be listed under srcGcsPath again.
+func (c *Client) MovePrefix(ctx context.Context, srcGcsPath string, dstGcsPath string, opts domain.MoveOptions) (*domain.MovePrefixResult, error) {
+	srcBucket, srcPrefix, err := splitGCSPath(srcGcsPath)
+	if err != nil {
+		return nil, err
+	}
+	dstBucket, dstPrefix, err := splitGCSPath(dstGcsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	it := c.client.Bucket(srcBucket).Objects(ctx, &storage.Query{Prefix: srcPrefix})
+	if opts.PageSize > 0 {
+		it.PageInfo().MaxSize = opts.PageSize
+	}
+
+	var candidates []*storage.ObjectAttrs
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, attrs)
+	}
+
+	// Batching by descending directory depth, with a barrier between batches, moves a
+	// "directory placeholder" object (named exactly like the prefix it represents) only after
+	// everything nested under it has been attempted, the same order DeleteGcsPrefix uses, so a
+	// partial failure leaves a consistent tree at both ends. A plain descending sort of the
+	// dispatch order isn't enough to guarantee this once Parallelism > 1.
+	depthBatches := batchByDescendingDepth(candidates)
+
+	parallelism := opts.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	moveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	result := &domain.MovePrefixResult{}
+	var mu sync.Mutex
+	sem := make(chan struct{}, parallelism)
+
+	for _, batch := range depthBatches {
+		var wg sync.WaitGroup
+		for _, attrs := range batch {
+			if moveCtx.Err() != nil {
+				break
+			}
+
+			attrs := attrs
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				dstName := dstPrefix + strings.TrimPrefix(attrs.Name, srcPrefix)
+				srcObj := c.GetObject(srcBucket, attrs.Name)
+				dstObj := c.GetObject(dstBucket, dstName)
+				srcObjPath := fmt.Sprintf("gs://%s/%s", srcBucket, attrs.Name)
+
+				if _, err := srcObj.Move(moveCtx, &storage.Conditions{DoesNotExist: true}, dstObj); err != nil {
+					mu.Lock()
+					defer mu.Unlock()
+					result.Errors = append(result.Errors, fmt.Errorf("%s: %w", srcObjPath, err))
+					if !opts.ContinueOnError {
+						cancel()
+					}
+					return
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				result.ObjectsMoved++
+				result.BytesMoved += attrs.Size
+			}()
+		}
+		// Wait out every goroutine dispatched in this batch, even one that just canceled
+		// moveCtx, before deciding whether to stop: the barrier has to hold regardless of why
+		// the batch ended, or a later batch could start moving while this one's moves (and the
+		// placeholder guarantee they exist to protect) are still in flight.
+		wg.Wait()
+		if moveCtx.Err() != nil {
+			break
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,49 @@
+//  Copyright 2026 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage
+
+import (
+	"strings"
+
+	"cloud.google.com/go/storage"
+)
+
+// batchByDescendingDepth groups candidates into batches ordered by descending directory depth,
+// for use as a dispatch-and-barrier plan: run a batch to completion before starting the next.
+// This is what lets DeleteGcsPrefix and MovePrefix keep their "placeholder only touched after
+// everything nested under it" guarantee even with Parallelism > 1 — objects within a batch run
+// concurrently with no ordering between them, but a "directory placeholder" object (named
+// exactly like the prefix it represents) always lands in a shallower batch than anything nested
+// under it, one level less deep than its own children, so the barrier between batches guarantees
+// those children are attempted first.
+func batchByDescendingDepth(candidates []*storage.ObjectAttrs) [][]*storage.ObjectAttrs {
+	byDepth := map[int][]*storage.ObjectAttrs{}
+	maxDepth := 0
+	for _, attrs := range candidates {
+		depth := strings.Count(strings.TrimSuffix(attrs.Name, "/"), "/")
+		byDepth[depth] = append(byDepth[depth], attrs)
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+	}
+
+	batches := make([][]*storage.ObjectAttrs, 0, maxDepth+1)
+	for depth := maxDepth; depth >= 0; depth-- {
+		if batch, ok := byDepth[depth]; ok {
+			batches = append(batches, batch)
+		}
+	}
+	return batches
+}
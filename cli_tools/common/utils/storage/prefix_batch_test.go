@@ -0,0 +1,65 @@
+//  Copyright 2026 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func attrsNamed(names ...string) []*storage.ObjectAttrs {
+	var attrs []*storage.ObjectAttrs
+	for _, name := range names {
+		attrs = append(attrs, &storage.ObjectAttrs{Name: name})
+	}
+	return attrs
+}
+
+func batchNames(batches [][]*storage.ObjectAttrs) [][]string {
+	var out [][]string
+	for _, batch := range batches {
+		var names []string
+		for _, attrs := range batch {
+			names = append(names, attrs.Name)
+		}
+		out = append(out, names)
+	}
+	return out
+}
+
+func TestBatchByDescendingDepth_PlaceholderLandsAfterItsChildren(t *testing.T) {
+	batches := batchByDescendingDepth(attrsNamed("dir/", "dir/a.txt", "dir/b.txt"))
+
+	assert.Equal(t, [][]string{{"dir/a.txt", "dir/b.txt"}, {"dir/"}}, batchNames(batches))
+}
+
+func TestBatchByDescendingDepth_NestedPlaceholdersOrderDeepestFirst(t *testing.T) {
+	batches := batchByDescendingDepth(attrsNamed("a/", "a/b/", "a/b/c.txt"))
+
+	assert.Equal(t, [][]string{{"a/b/c.txt"}, {"a/b/"}, {"a/"}}, batchNames(batches))
+}
+
+func TestBatchByDescendingDepth_SiblingsAtSameDepthShareABatch(t *testing.T) {
+	batches := batchByDescendingDepth(attrsNamed("a/x.txt", "a/y.txt", "b/z.txt"))
+
+	assert.Len(t, batches, 1)
+	assert.ElementsMatch(t, []string{"a/x.txt", "a/y.txt", "b/z.txt"}, batchNames(batches)[0])
+}
+
+func TestBatchByDescendingDepth_Empty(t *testing.T) {
+	assert.Empty(t, batchByDescendingDepth(nil))
+}
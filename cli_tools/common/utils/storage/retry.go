@@ -0,0 +1,86 @@
+//  Copyright 2026 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/GoogleCloudPlatform/compute-image-import/cli_tools/common/domain"
+)
+
+// ClientOption configures a Client at construction. See WithDefaultRetryPolicy.
+type ClientOption func(*Client)
+
+// WithDefaultRetryPolicy overrides the RetryPolicy a Client applies to a call that doesn't pass
+// its own domain.RetryOption. Without this option, a Client uses domain.DefaultRetryPolicy.
+func WithDefaultRetryPolicy(policy domain.RetryPolicy) ClientOption {
+	return func(c *Client) { c.retryPolicy = policy }
+}
+
+// resolveRetryPolicy starts from c.retryPolicy and applies opts on top of it.
+func (c *Client) resolveRetryPolicy(opts ...domain.RetryOption) domain.RetryPolicy {
+	policy := c.retryPolicy
+	for _, opt := range opts {
+		opt(&policy)
+	}
+	return policy
+}
+
+// withRetry runs fn, retrying it according to policy. idempotent must be false for a call
+// (WriteToGCS, DeleteObject) whose effect can't be safely repeated without either a precondition
+// or the caller's explicit AllowNonIdempotentRetry; withRetry then tries fn exactly once
+// regardless of policy.MaxAttempts, unless policy.AllowNonIdempotentRetry is set.
+func withRetry(ctx context.Context, policy domain.RetryPolicy, idempotent bool, fn func() error) error {
+	maxAttempts := policy.MaxAttempts
+	if !idempotent && !policy.AllowNonIdempotentRetry {
+		maxAttempts = 1
+	}
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	isRetryable := policy.IsRetryable
+	if isRetryable == nil {
+		isRetryable = domain.IsRetryableError
+	}
+
+	backoff := policy.InitialBackoff
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		lastErr = fn()
+		if lastErr == nil {
+			return nil
+		}
+		if attempt == maxAttempts || !isRetryable(lastErr) {
+			return lastErr
+		}
+
+		wait := backoff
+		if policy.Jitter > 0 {
+			wait += time.Duration(rand.Float64() * policy.Jitter * float64(backoff))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+		if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+	return lastErr
+}
@@ -0,0 +1,105 @@
+//  Copyright 2026 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GoogleCloudPlatform/compute-image-import/cli_tools/common/domain"
+)
+
+func TestWithRetry_SucceedsAfterTransientFailures(t *testing.T) {
+	policy := domain.RetryPolicy{MaxAttempts: 3, IsRetryable: func(error) bool { return true }}
+	attempts := 0
+
+	err := withRetry(context.Background(), policy, true, func() error {
+		attempts++
+		if attempts < 3 {
+			return fmt.Errorf("transient")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestWithRetry_NonRetryableError_StopsImmediately(t *testing.T) {
+	policy := domain.RetryPolicy{MaxAttempts: 5, IsRetryable: func(error) bool { return false }}
+	attempts := 0
+
+	err := withRetry(context.Background(), policy, true, func() error {
+		attempts++
+		return fmt.Errorf("permanent")
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWithRetry_NonIdempotent_TriesOnlyOnce(t *testing.T) {
+	policy := domain.RetryPolicy{MaxAttempts: 5, IsRetryable: func(error) bool { return true }}
+	attempts := 0
+
+	err := withRetry(context.Background(), policy, false, func() error {
+		attempts++
+		return fmt.Errorf("transient")
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWithRetry_NonIdempotentButAllowed_Retries(t *testing.T) {
+	policy := domain.RetryPolicy{MaxAttempts: 3, IsRetryable: func(error) bool { return true }, AllowNonIdempotentRetry: true}
+	attempts := 0
+
+	err := withRetry(context.Background(), policy, false, func() error {
+		attempts++
+		if attempts < 2 {
+			return fmt.Errorf("transient")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestWithRetry_ExhaustsAttempts_ReturnsLastError(t *testing.T) {
+	policy := domain.RetryPolicy{MaxAttempts: 2, IsRetryable: func(error) bool { return true }}
+	attempts := 0
+
+	err := withRetry(context.Background(), policy, true, func() error {
+		attempts++
+		return fmt.Errorf("attempt %d", attempts)
+	})
+	require.Error(t, err)
+	assert.Equal(t, "attempt 2", err.Error())
+	assert.Equal(t, 2, attempts)
+}
+
+func TestWithRetry_CanceledContext_StopsWaitingBetweenAttempts(t *testing.T) {
+	policy := domain.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour, IsRetryable: func(error) bool { return true }}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := withRetry(ctx, policy, true, func() error {
+		return fmt.Errorf("transient")
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,290 @@
+//  Copyright 2026 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package storage provides the concrete implementation of the storage domain interfaces
+// (cli_tools/common/domain), backed by cloud.google.com/go/storage.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/compute-image-import/cli_tools/common/domain"
+)
+
+// Client is the concrete implementation of domain.StorageClientInterface, backed by a
+// *storage.Client.
+type Client struct {
+	client      *storage.Client
+	retryPolicy domain.RetryPolicy
+}
+
+// NewStorageClient returns a domain.StorageClientInterface backed by client. By default it
+// retries failing calls according to domain.DefaultRetryPolicy; pass WithDefaultRetryPolicy to
+// change that.
+func NewStorageClient(client *storage.Client, opts ...ClientOption) domain.StorageClientInterface {
+	c := &Client{client: client, retryPolicy: domain.DefaultRetryPolicy()}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CreateBucket creates a bucket with the given attrs in project.
+func (c *Client) CreateBucket(ctx context.Context, bucketName string, project string, attrs *storage.BucketAttrs) error {
+	return c.client.Bucket(bucketName).Create(ctx, project, attrs)
+}
+
+// UpdateBucket updates the bucket's attrs.
+func (c *Client) UpdateBucket(ctx context.Context, bucketName string, attrs storage.BucketAttrsToUpdate) error {
+	_, err := c.client.Bucket(bucketName).Update(ctx, attrs)
+	return err
+}
+
+// Buckets returns an iterator over the buckets in projectID.
+func (c *Client) Buckets(ctx context.Context, projectID string) *storage.BucketIterator {
+	return c.client.Buckets(ctx, projectID)
+}
+
+// GetBucketAttrs returns the attrs of bucket.
+func (c *Client) GetBucketAttrs(ctx context.Context, bucket string) (*storage.BucketAttrs, error) {
+	return c.client.Bucket(bucket).Attrs(ctx)
+}
+
+// GetBucket returns a handle to bucket.
+func (c *Client) GetBucket(ctx context.Context, bucket string) *storage.BucketHandle {
+	return c.client.Bucket(bucket)
+}
+
+// GetObject returns a domain.StorageObject for the object at bucket/objectPath.
+func (c *Client) GetObject(bucket string, objectPath string) domain.StorageObject {
+	return &Object{client: c.client, handle: c.client.Bucket(bucket).Object(objectPath)}
+}
+
+// GetObjects returns an iterator over objects in bucket under objectPath. The returned
+// iterator's Next is bound to whatever ctx it's called with, but the underlying SDK iterator
+// created here is bound to ctx for the lifetime of the listing, so canceling ctx also aborts an
+// in-flight page fetch rather than only rejecting calls made after the fact.
+func (c *Client) GetObjects(ctx context.Context, bucket string, objectPath string) domain.ObjectIteratorInterface {
+	return &objectIterator{it: c.client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: objectPath})}
+}
+
+// objectIterator adapts *storage.ObjectIterator to domain.ObjectIteratorInterface, whose Next
+// takes a context so callers can bound or cancel a listing in progress.
+type objectIterator struct {
+	it *storage.ObjectIterator
+}
+
+// Next returns the attrs of the next object, or ctx.Err() if ctx is already canceled when Next
+// is called. A cancellation of ctx during the underlying SDK call is not observed here; that
+// call is bound to the context the iterator was created with.
+func (o *objectIterator) Next(ctx context.Context) (*storage.ObjectAttrs, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return o.it.Next()
+}
+
+// GetObjectAttrs returns the attrs of the object at bucket/objectPath.
+func (c *Client) GetObjectAttrs(ctx context.Context, bucket string, objectPath string) (*storage.ObjectAttrs, error) {
+	return c.client.Bucket(bucket).Object(objectPath).Attrs(ctx)
+}
+
+// FindGcsFile finds the first object under gcsDirectoryPath with the given extension.
+func (c *Client) FindGcsFile(ctx context.Context, gcsDirectoryPath string, fileExtension string, opts ...domain.RetryOption) (*storage.ObjectHandle, error) {
+	return c.FindGcsFileDepthLimited(ctx, gcsDirectoryPath, fileExtension, -1, opts...)
+}
+
+// FindGcsFileDepthLimited finds the first object under gcsDirectoryPath with the given
+// extension, not descending more than lookupDepth levels below gcsDirectoryPath. A negative
+// lookupDepth means unlimited depth. It returns ctx.Err() as soon as ctx is canceled.
+func (c *Client) FindGcsFileDepthLimited(ctx context.Context, gcsDirectoryPath string, fileExtension string, lookupDepth int, opts ...domain.RetryOption) (*storage.ObjectHandle, error) {
+	var result *storage.ObjectHandle
+	err := withRetry(ctx, c.resolveRetryPolicy(opts...), true, func() error {
+		found, err := c.findGcsFileDepthLimited(ctx, gcsDirectoryPath, fileExtension, lookupDepth)
+		result = found
+		return err
+	})
+	return result, err
+}
+
+func (c *Client) findGcsFileDepthLimited(ctx context.Context, gcsDirectoryPath string, fileExtension string, lookupDepth int) (*storage.ObjectHandle, error) {
+	bucketName, objectPath, err := splitGCSPath(gcsDirectoryPath)
+	if err != nil {
+		return nil, err
+	}
+	baseDepth := strings.Count(objectPath, "/")
+
+	it := c.client.Bucket(bucketName).Objects(ctx, &storage.Query{Prefix: objectPath})
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasSuffix(attrs.Name, fileExtension) {
+			continue
+		}
+		if lookupDepth >= 0 && strings.Count(attrs.Name, "/")-baseDepth > lookupDepth {
+			continue
+		}
+		return c.client.Bucket(bucketName).Object(attrs.Name), nil
+	}
+	return nil, fmt.Errorf("no file with extension %q found under %q", fileExtension, gcsDirectoryPath)
+}
+
+// GetGcsFileContent reads the full contents of gcsObject, bound to ctx: canceling ctx aborts the
+// read and the returned error wraps ctx.Err().
+func (c *Client) GetGcsFileContent(ctx context.Context, gcsObject *storage.ObjectHandle, opts ...domain.RetryOption) ([]byte, error) {
+	var content []byte
+	err := withRetry(ctx, c.resolveRetryPolicy(opts...), true, func() error {
+		reader, err := gcsObject.NewReader(ctx)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		content, err = io.ReadAll(reader)
+		return err
+	})
+	return content, err
+}
+
+// WriteToGCS writes reader's contents to destinationBucketName/destinationObjectPath. See
+// domain.StorageClientInterface for the semantics of conditions. The write is bound to ctx:
+// canceling ctx aborts the upload and the returned error wraps ctx.Err().
+func (c *Client) WriteToGCS(ctx context.Context, destinationBucketName string, destinationObjectPath string, reader io.Reader,
+	conditions *storage.Conditions, opts ...domain.RetryOption) error {
+	policy := c.resolveRetryPolicy(opts...)
+	idempotent := conditions != nil || policy.AllowNonIdempotentRetry
+	// A retry has to replay reader from the start, which only a Seeker can do safely; without
+	// one, a "retry" would just upload whatever's left of an already partially-drained stream.
+	seeker, seekable := reader.(io.Seeker)
+	if !seekable {
+		idempotent = false
+		policy.AllowNonIdempotentRetry = false
+	}
+	return withRetry(ctx, policy, idempotent, func() error {
+		if seekable {
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+		}
+		handle := c.client.Bucket(destinationBucketName).Object(destinationObjectPath)
+		if conditions != nil {
+			handle = handle.If(*conditions)
+		}
+		writer := handle.NewWriter(ctx)
+		if _, err := io.Copy(writer, reader); err != nil {
+			writer.Close()
+			return err
+		}
+		if err := writer.Close(); err != nil {
+			return wrapPreconditionError(gcsPath(destinationBucketName, destinationObjectPath), err)
+		}
+		return nil
+	})
+}
+
+// DeleteGcsPath deletes every object under gcsPath. See domain.StorageClientInterface for the
+// semantics of conditions and of ctx cancellation.
+func (c *Client) DeleteGcsPath(ctx context.Context, gcsPath string, conditions *storage.Conditions) error {
+	bucketName, objectPath, err := splitGCSPath(gcsPath)
+	if err != nil {
+		return err
+	}
+	it := c.client.Bucket(bucketName).Objects(ctx, &storage.Query{Prefix: objectPath})
+	var errs []error
+	for {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if delErr := c.DeleteObject(ctx, fmt.Sprintf("gs://%s/%s", bucketName, attrs.Name), conditions); delErr != nil {
+			errs = append(errs, delErr)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DeleteObject deletes the single object at gcsPath. See domain.StorageClientInterface for the
+// semantics of conditions.
+func (c *Client) DeleteObject(ctx context.Context, gcsPath string, conditions *storage.Conditions, opts ...domain.RetryOption) error {
+	policy := c.resolveRetryPolicy(opts...)
+	return withRetry(ctx, policy, conditions != nil, func() error {
+		bucketName, objectPath, err := splitGCSPath(gcsPath)
+		if err != nil {
+			return err
+		}
+		handle := c.client.Bucket(bucketName).Object(objectPath)
+		if conditions != nil {
+			handle = handle.If(*conditions)
+		}
+		if err := handle.Delete(ctx); err != nil {
+			return wrapPreconditionError(gcsPath, err)
+		}
+		return nil
+	})
+}
+
+// Close releases resources held by the underlying storage client.
+func (c *Client) Close() error {
+	return c.client.Close()
+}
+
+// wrapPreconditionError returns a *domain.PreconditionFailedError when err indicates a failed
+// GCS precondition (HTTP 412), and err unchanged otherwise.
+func wrapPreconditionError(gcsPath string, err error) error {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == 412 {
+		return &domain.PreconditionFailedError{GcsPath: gcsPath, Cause: err}
+	}
+	return err
+}
+
+// splitGCSPath splits a gs://bucket/object path into its bucket and object components.
+func splitGCSPath(gcsPath string) (bucket string, object string, err error) {
+	u, err := url.Parse(gcsPath)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid GCS path %q: %v", gcsPath, err)
+	}
+	if u.Scheme != "gs" || u.Host == "" {
+		return "", "", fmt.Errorf("invalid GCS path %q: expected gs://bucket/object", gcsPath)
+	}
+	return u.Host, strings.TrimPrefix(u.Path, "/"), nil
+}
+
+func gcsPath(bucket, object string) string {
+	return fmt.Sprintf("gs://%s/%s", bucket, object)
+}
@@ -0,0 +1,52 @@
+//  Copyright 2026 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+
+	"github.com/GoogleCloudPlatform/compute-image-import/cli_tools/common/domain"
+)
+
+func TestWrapPreconditionError_PreconditionMismatch_ReturnsPreconditionFailedError(t *testing.T) {
+	err := wrapPreconditionError("gs://bucket/object", &googleapi.Error{Code: 412})
+
+	var preconditionErr *domain.PreconditionFailedError
+	assert.True(t, errors.As(err, &preconditionErr))
+	assert.Equal(t, "gs://bucket/object", preconditionErr.GcsPath)
+}
+
+func TestWrapPreconditionError_NotFound_IsNotAPreconditionError(t *testing.T) {
+	err := wrapPreconditionError("gs://bucket/object", &googleapi.Error{Code: 404})
+
+	var preconditionErr *domain.PreconditionFailedError
+	assert.False(t, errors.As(err, &preconditionErr))
+}
+
+func TestSplitGCSPath(t *testing.T) {
+	bucket, object, err := splitGCSPath("gs://my-bucket/dir/object.tar")
+	assert.NoError(t, err)
+	assert.Equal(t, "my-bucket", bucket)
+	assert.Equal(t, "dir/object.tar", object)
+}
+
+func TestSplitGCSPath_Invalid(t *testing.T) {
+	_, _, err := splitGCSPath("not-a-gcs-path")
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+//  Copyright 2026 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/GoogleCloudPlatform/compute-image-import/cli_tools/common/domain"
+)
+
+// Object is the concrete implementation of domain.StorageObject, backed by a
+// *storage.ObjectHandle.
+type Object struct {
+	client *storage.Client
+	handle *storage.ObjectHandle
+}
+
+// Delete removes the object. See domain.StorageObject for the semantics of conditions.
+func (o *Object) Delete(ctx context.Context, conditions *storage.Conditions) error {
+	handle := o.handle
+	if conditions != nil {
+		handle = handle.If(*conditions)
+	}
+	if err := handle.Delete(ctx); err != nil {
+		return wrapPreconditionError(gcsPath(o.handle.BucketName(), o.handle.ObjectName()), err)
+	}
+	return nil
+}
+
+// GetObjectHandle returns the underlying *storage.ObjectHandle.
+func (o *Object) GetObjectHandle() *storage.ObjectHandle {
+	return o.handle
+}
+
+// NewReader returns a reader for the object's current contents.
+func (o *Object) NewReader(ctx context.Context) (io.ReadCloser, error) {
+	return o.handle.NewReader(ctx)
+}
+
+// NewWriter returns a writer that creates or overwrites the object. See domain.StorageObject
+// for the semantics of conditions and ctx.
+func (o *Object) NewWriter(ctx context.Context, conditions *storage.Conditions) io.WriteCloser {
+	handle := o.handle
+	if conditions != nil {
+		handle = handle.If(*conditions)
+	}
+	return &preconditionWriter{Writer: handle.NewWriter(ctx), gcsPath: gcsPath(o.handle.BucketName(), o.handle.ObjectName())}
+}
+
+// preconditionWriter reports a failed precondition on Close as a *domain.PreconditionFailedError.
+type preconditionWriter struct {
+	*storage.Writer
+	gcsPath string
+}
+
+func (w *preconditionWriter) Close() error {
+	if err := w.Writer.Close(); err != nil {
+		return wrapPreconditionError(w.gcsPath, err)
+	}
+	return nil
+}
+
+// ObjectName returns the object's name.
+func (o *Object) ObjectName() string {
+	return o.handle.ObjectName()
+}
+
+// Compose combines src into this object. See domain.StorageObject for the semantics of
+// conditions.
+func (o *Object) Compose(ctx context.Context, conditions *storage.Conditions, src ...domain.StorageObject) (*storage.ObjectAttrs, error) {
+	handles := make([]*storage.ObjectHandle, 0, len(src))
+	for _, s := range src {
+		handles = append(handles, s.GetObjectHandle())
+	}
+	handle := o.handle
+	if conditions != nil {
+		handle = handle.If(*conditions)
+	}
+	attrs, err := handle.ComposerFrom(handles...).Run(ctx)
+	if err != nil {
+		return nil, wrapPreconditionError(gcsPath(o.handle.BucketName(), o.handle.ObjectName()), err)
+	}
+	return attrs, nil
+}
+
+// CopyFrom copies src into this object. See domain.StorageObject for the semantics of
+// conditions.
+func (o *Object) CopyFrom(ctx context.Context, conditions *storage.Conditions, src domain.StorageObject) (*storage.ObjectAttrs, error) {
+	handle := o.handle
+	if conditions != nil {
+		handle = handle.If(*conditions)
+	}
+	attrs, err := handle.CopierFrom(src.GetObjectHandle()).Run(ctx)
+	if err != nil {
+		return nil, wrapPreconditionError(gcsPath(o.handle.BucketName(), o.handle.ObjectName()), err)
+	}
+	return attrs, nil
+}
+
+// Move copies this object into dst and then deletes this object. See domain.StorageObject for
+// the semantics of conditions and of the source-delete precondition.
+func (o *Object) Move(ctx context.Context, conditions *storage.Conditions, dst domain.StorageObject) (*storage.ObjectAttrs, error) {
+	srcAttrs, err := o.handle.Attrs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Pin the copy, not just the delete, to the generation observed above: without this, a
+	// concurrent overwrite of the source between the Attrs call and the copy would copy the
+	// newer content to dst while the delete below still (correctly) fails on the stale
+	// generation, silently moving the wrong version.
+	pinnedSrc := &Object{client: o.client, handle: o.handle.Generation(srcAttrs.Generation)}
+	attrs, err := dst.CopyFrom(ctx, conditions, pinnedSrc)
+	if err != nil {
+		return nil, err
+	}
+	srcPath := gcsPath(o.handle.BucketName(), o.handle.ObjectName())
+	if err := o.Delete(ctx, &storage.Conditions{GenerationMatch: srcAttrs.Generation}); err != nil {
+		return nil, fmt.Errorf("move %s: copied to destination but failed to delete source: %w", srcPath, err)
+	}
+	return attrs, nil
+}